@@ -3,12 +3,24 @@ package combustion
 import (
 	"encoding/hex"
 	"slices"
+	"sync"
 
 	"tinygo.org/x/bluetooth"
 )
 
 const CombustionManufacuterID = 0x09C7
 
+// TemperatureUnit selects the unit CombustionPacket's CoreTemp, SurfaceTemp,
+// and AmbientTemp helpers report in. Raw readings are always stored and
+// decoded in Celsius; TemperatureUnit only affects those helpers.
+type TemperatureUnit byte
+
+const (
+	CombustionUnitCelsius    TemperatureUnit = 0
+	CombustionUnitFahrenheit TemperatureUnit = 1
+	CombustionUnitKelvin     TemperatureUnit = 2
+)
+
 // See https://github.com/combustion-inc/combustion-documentation/blob/main/meatnet_node_ble_specification.rst#product-type
 type CombustionProductType byte
 
@@ -52,11 +64,57 @@ type CombustionPacket struct {
 	VirtualSurfaceIndex byte
 	VirtualAmbientIndex byte
 	Overheating         [8]bool
+	Unit                TemperatureUnit
+}
+
+// CoreTemp returns the virtual core sensor's reading, converted to the
+// packet's Unit. It returns 0 in CombustionModeInstantRead, where Temps only
+// has the single instant-read value and VirtualCoreIndex doesn't apply.
+func (p *CombustionPacket) CoreTemp() float32 {
+	return p.virtualTemp(p.VirtualCoreIndex)
+}
+
+// SurfaceTemp returns the virtual surface sensor's reading, converted to
+// the packet's Unit. It returns 0 in CombustionModeInstantRead, where Temps
+// only has the single instant-read value and VirtualSurfaceIndex doesn't
+// apply.
+func (p *CombustionPacket) SurfaceTemp() float32 {
+	return p.virtualTemp(p.VirtualSurfaceIndex)
+}
+
+// AmbientTemp returns the virtual ambient sensor's reading, converted to
+// the packet's Unit. It returns 0 in CombustionModeInstantRead, where Temps
+// only has the single instant-read value and VirtualAmbientIndex doesn't
+// apply.
+func (p *CombustionPacket) AmbientTemp() float32 {
+	return p.virtualTemp(p.VirtualAmbientIndex)
+}
+
+func (p *CombustionPacket) virtualTemp(index byte) float32 {
+	if p.Mode == CombustionModeInstantRead || int(index) >= len(p.Temps) {
+		return 0
+	}
+	return p.convert(p.Temps[index])
+}
+
+func (p *CombustionPacket) convert(celsius float32) float32 {
+	switch p.Unit {
+	case CombustionUnitFahrenheit:
+		return (celsius * 9 / 5) + 32
+	case CombustionUnitKelvin:
+		return celsius + 273.15
+	default:
+		return celsius
+	}
 }
 
 type Combustion struct {
 	BluetoothAdapter *bluetooth.Adapter
+	TemperatureUnit  TemperatureUnit
 	packetHandler    func(*Combustion, CombustionPacket)
+
+	mu         sync.Mutex
+	predictors []*Predictor
 }
 
 // Temperature = (raw value * 0.05) - 20
@@ -78,6 +136,7 @@ func (c *Combustion) ExtractCombustionPacket(rawPacket []byte) *CombustionPacket
 		ProductType:  CombustionUnknownPT,
 		SerialNumber: "",
 		BatteryOK:    false,
+		Unit:         c.TemperatureUnit,
 	}
 
 	packet.ProductType = CombustionProductType(rawPacket[0])
@@ -134,6 +193,8 @@ func (c *Combustion) onScan(adapter *bluetooth.Adapter, device bluetooth.ScanRes
 		if c.packetHandler != nil {
 			c.packetHandler(c, *packet)
 		}
+
+		c.feedPredictors(*packet)
 	}
 }
 
@@ -0,0 +1,145 @@
+// Package exporter wraps Combustion.StartMonitoring and exposes probe
+// readings as Prometheus gauges, so users can chart cooks in Grafana
+// without writing their own callback plumbing.
+package exporter
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	combustion "github.com/carsonmcdonald/go-combustion"
+)
+
+// Exporter hooks a Combustion's packet handler and mirrors every reading
+// onto a set of Prometheus gauges keyed by serial_number and probe_id.
+type Exporter struct {
+	combustion *combustion.Combustion
+	registry   *prometheus.Registry
+
+	temperature         *prometheus.GaugeVec
+	batteryOK           *prometheus.GaugeVec
+	overheating         *prometheus.GaugeVec
+	virtualCoreIndex    *prometheus.GaugeVec
+	virtualSurfaceIndex *prometheus.GaugeVec
+	virtualAmbientIndex *prometheus.GaugeVec
+	lastSeen            *prometheus.GaugeVec
+}
+
+// New creates an Exporter for c. Call Start to begin monitoring and Handler
+// to obtain the HTTP handler to mount.
+func New(c *combustion.Combustion) *Exporter {
+	labels := []string{"serial_number", "probe_id"}
+
+	e := &Exporter{
+		combustion: c,
+		registry:   prometheus.NewRegistry(),
+		temperature: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "combustion",
+			Subsystem: "probe",
+			Name:      "temperature_celsius",
+			Help:      "Thermistor temperature in degrees Celsius.",
+		}, append(labels, "thermistor")),
+		batteryOK: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "combustion",
+			Subsystem: "probe",
+			Name:      "battery_ok",
+			Help:      "1 if the probe battery status is OK, 0 otherwise.",
+		}, labels),
+		overheating: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "combustion",
+			Subsystem: "probe",
+			Name:      "overheating",
+			Help:      "1 if the given thermistor is flagged as overheating, 0 otherwise.",
+		}, append(labels, "thermistor")),
+		virtualCoreIndex: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "combustion",
+			Subsystem: "probe",
+			Name:      "virtual_core_index",
+			Help:      "Thermistor index currently selected as the virtual core sensor.",
+		}, labels),
+		virtualSurfaceIndex: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "combustion",
+			Subsystem: "probe",
+			Name:      "virtual_surface_index",
+			Help:      "Thermistor index currently selected as the virtual surface sensor.",
+		}, labels),
+		virtualAmbientIndex: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "combustion",
+			Subsystem: "probe",
+			Name:      "virtual_ambient_index",
+			Help:      "Thermistor index currently selected as the virtual ambient sensor.",
+		}, labels),
+		lastSeen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "combustion",
+			Subsystem: "probe",
+			Name:      "last_seen_timestamp_seconds",
+			Help:      "Unix timestamp of the last packet received from the probe.",
+		}, labels),
+	}
+
+	e.registry.MustRegister(
+		e.temperature,
+		e.batteryOK,
+		e.overheating,
+		e.virtualCoreIndex,
+		e.virtualSurfaceIndex,
+		e.virtualAmbientIndex,
+		e.lastSeen,
+	)
+
+	return e
+}
+
+// Start begins monitoring for advertisements and updating the exported
+// metrics. It blocks the same way Combustion.StartMonitoring does.
+func (e *Exporter) Start() error {
+	return e.combustion.StartMonitoring(e.observe)
+}
+
+// Handler returns the HTTP handler serving the exported metrics in
+// Prometheus/OpenMetrics text format.
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+func (e *Exporter) observe(_ *combustion.Combustion, packet combustion.CombustionPacket) {
+	labels := prometheus.Labels{
+		"serial_number": packet.SerialNumber,
+		"probe_id":      strconv.Itoa(int(packet.ProbeID)),
+	}
+
+	for i, temp := range packet.Temps {
+		e.temperature.With(withLabel(labels, "thermistor", fmt.Sprintf("T%d", i+1))).Set(float64(temp))
+	}
+
+	for i, overheating := range packet.Overheating {
+		e.overheating.With(withLabel(labels, "thermistor", fmt.Sprintf("T%d", i+1))).Set(boolToFloat(overheating))
+	}
+
+	e.batteryOK.With(labels).Set(boolToFloat(packet.BatteryOK))
+	e.virtualCoreIndex.With(labels).Set(float64(packet.VirtualCoreIndex))
+	e.virtualSurfaceIndex.With(labels).Set(float64(packet.VirtualSurfaceIndex))
+	e.virtualAmbientIndex.With(labels).Set(float64(packet.VirtualAmbientIndex))
+	e.lastSeen.With(labels).Set(float64(time.Now().Unix()))
+}
+
+func withLabel(labels prometheus.Labels, key, value string) prometheus.Labels {
+	merged := make(prometheus.Labels, len(labels)+1)
+	for k, v := range labels {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
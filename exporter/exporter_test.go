@@ -0,0 +1,44 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	combustion "github.com/carsonmcdonald/go-combustion"
+)
+
+func TestExporterObserve(t *testing.T) {
+	e := New(&combustion.Combustion{})
+
+	packet := combustion.CombustionPacket{
+		SerialNumber: "abc123",
+		ProbeID:      1,
+		Temps:        []float32{21, 22, 23, 24, 25, 26, 27, 28},
+		BatteryOK:    true,
+		Overheating:  [8]bool{false, false, true, false, false, false, false, false},
+	}
+
+	e.observe(nil, packet)
+
+	labels := prometheus.Labels{"serial_number": "abc123", "probe_id": "1"}
+
+	if got := testutil.ToFloat64(e.temperature.With(withLabel(labels, "thermistor", "T1"))); got != 21 {
+		t.Errorf("temperature T1 = %v, want 21", got)
+	}
+	if got := testutil.ToFloat64(e.temperature.With(withLabel(labels, "thermistor", "T3"))); got != 23 {
+		t.Errorf("temperature T3 = %v, want 23", got)
+	}
+
+	if got := testutil.ToFloat64(e.batteryOK.With(labels)); got != 1 {
+		t.Errorf("batteryOK = %v, want 1", got)
+	}
+
+	if got := testutil.ToFloat64(e.overheating.With(withLabel(labels, "thermistor", "T3"))); got != 1 {
+		t.Errorf("overheating T3 = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(e.overheating.With(withLabel(labels, "thermistor", "T1"))); got != 0 {
+		t.Errorf("overheating T1 = %v, want 0", got)
+	}
+}
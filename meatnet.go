@@ -0,0 +1,144 @@
+package combustion
+
+import (
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// probeSightingTTL bounds how long a "best" sighting for a probe is held
+// against new sightings. Without it, a single strong/low-hop reading would
+// permanently win and every later advertisement -- even ones that are the
+// only signal still arriving -- would be dropped as "worse".
+const probeSightingTTL = 5 * time.Second
+
+// See https://github.com/combustion-inc/combustion-documentation/blob/main/meatnet_node_ble_specification.rst#product-type
+const (
+	CombustionMeatNetRepeaterPT CombustionProductType = 2
+	CombustionGiantGrillGaugePT CombustionProductType = 3
+	CombustionDisplayPT         CombustionProductType = 4
+	CombustionBoosterPT         CombustionProductType = 5
+)
+
+// NodePacket is a probe advertisement re-broadcast by a MeatNet repeater
+// node (Giant Grill Gauge, Display, or Booster) rather than observed
+// directly from the probe.
+type NodePacket struct {
+	NodeProductType  CombustionProductType
+	NodeSerialNumber string
+	HopCount         byte
+	RSSIAtHop        int8
+	CombustionPacket
+}
+
+// ProbeUpdate is delivered by StartMonitoringMulti for every probe seen,
+// whether observed directly or re-advertised by a MeatNet repeater node.
+type ProbeUpdate struct {
+	SourceNode *NodePacket // nil when the probe was observed directly
+	HopCount   byte
+	CombustionPacket
+}
+
+// See https://github.com/combustion-inc/combustion-documentation/blob/main/meatnet_node_ble_specification.rst#repeater-advertising-data
+// Data Value           Bytes  	Description
+// Product Type			1	   	See Product Type (2-5 for repeater nodes).
+// Node Serial Number	4      	Repeater node's own serial number.
+// Hop Count			1		Number of repeater hops from the source probe.
+// RSSI at Hop			1		Signed RSSI the node observed from the probe.
+// Probe Raw Data		22		Direct-advertisement layout for the repeated probe.
+func (c *Combustion) ExtractNodePacket(rawPacket []byte) *NodePacket {
+	packet := &NodePacket{
+		NodeProductType: CombustionProductType(rawPacket[0]),
+	}
+
+	slices.Reverse(rawPacket[1:5])
+	packet.NodeSerialNumber = hex.EncodeToString(rawPacket[1:5])
+
+	packet.HopCount = rawPacket[5]
+	packet.RSSIAtHop = int8(rawPacket[6])
+
+	packet.CombustionPacket = *c.ExtractCombustionPacket(rawPacket[7:29])
+
+	return packet
+}
+
+// probeSighting tracks the best path seen recently for a given probe serial
+// number, so StartMonitoringMulti can drop duplicate/worse hops without
+// permanently squelching the stream once a strong sighting is recorded.
+type probeSighting struct {
+	hopCount byte
+	rssi     int8
+	seenAt   time.Time
+}
+
+// better reports whether a newly observed sighting should replace the
+// current best for a probe: a best sighting older than probeSightingTTL is
+// treated as stale and always replaced, otherwise fewer hops wins outright
+// and ties are broken by the stronger (less negative) RSSI.
+func (s probeSighting) better(candidate probeSighting) bool {
+	if candidate.seenAt.Sub(s.seenAt) > probeSightingTTL {
+		return true
+	}
+	if candidate.hopCount != s.hopCount {
+		return candidate.hopCount < s.hopCount
+	}
+	return candidate.rssi > s.rssi
+}
+
+// StartMonitoringMulti is like StartMonitoring but also understands MeatNet
+// repeater node advertisements (Giant Grill Gauge, Display, Booster). When
+// the same probe is visible both directly and through one or more repeater
+// hops, only the best path (lowest hop count, then strongest RSSI) is
+// delivered for each advertisement, so callers never see duplicate or
+// conflicting updates for one probe.
+func (c *Combustion) StartMonitoringMulti(callback func(*Combustion, ProbeUpdate)) error {
+	if c.BluetoothAdapter == nil {
+		c.BluetoothAdapter = bluetooth.DefaultAdapter
+	}
+
+	if err := c.BluetoothAdapter.Enable(); err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	best := make(map[string]probeSighting)
+
+	return c.BluetoothAdapter.Scan(func(adapter *bluetooth.Adapter, device bluetooth.ScanResult) {
+		md := device.AdvertisementPayload.ManufacturerData()
+		if len(md) == 0 || md[0].CompanyID != CombustionManufacuterID {
+			return
+		}
+
+		raw := md[0].Data
+		update := ProbeUpdate{}
+		now := time.Now()
+		sighting := probeSighting{hopCount: 0, rssi: int8(device.RSSI), seenAt: now}
+
+		if CombustionProductType(raw[0]) == CombustionPredictiveProbePT {
+			update.CombustionPacket = *c.ExtractCombustionPacket(raw)
+		} else {
+			node := c.ExtractNodePacket(raw)
+			update.SourceNode = node
+			update.HopCount = node.HopCount
+			update.CombustionPacket = node.CombustionPacket
+			sighting = probeSighting{hopCount: node.HopCount, rssi: node.RSSIAtHop, seenAt: now}
+		}
+
+		c.feedPredictors(update.CombustionPacket)
+
+		mu.Lock()
+		current, seen := best[update.SerialNumber]
+		replace := !seen || current.better(sighting)
+		if replace {
+			best[update.SerialNumber] = sighting
+		}
+		mu.Unlock()
+
+		if replace {
+			callback(c, update)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package combustion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProbeSightingBetter(t *testing.T) {
+	base := time.Now()
+
+	tests := []struct {
+		name      string
+		current   probeSighting
+		candidate probeSighting
+		want      bool
+	}{
+		{
+			name:      "fewer hops wins",
+			current:   probeSighting{hopCount: 2, rssi: -40, seenAt: base},
+			candidate: probeSighting{hopCount: 1, rssi: -80, seenAt: base},
+			want:      true,
+		},
+		{
+			name:      "more hops loses",
+			current:   probeSighting{hopCount: 1, rssi: -80, seenAt: base},
+			candidate: probeSighting{hopCount: 2, rssi: -40, seenAt: base},
+			want:      false,
+		},
+		{
+			name:      "same hop count, stronger RSSI wins",
+			current:   probeSighting{hopCount: 1, rssi: -80, seenAt: base},
+			candidate: probeSighting{hopCount: 1, rssi: -40, seenAt: base},
+			want:      true,
+		},
+		{
+			name:      "same hop count, weaker RSSI loses",
+			current:   probeSighting{hopCount: 1, rssi: -40, seenAt: base},
+			candidate: probeSighting{hopCount: 1, rssi: -80, seenAt: base},
+			want:      false,
+		},
+		{
+			name:      "stale best is always replaced even by a weaker sighting",
+			current:   probeSighting{hopCount: 1, rssi: -40, seenAt: base},
+			candidate: probeSighting{hopCount: 2, rssi: -80, seenAt: base.Add(probeSightingTTL + time.Second)},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.current.better(tt.candidate); got != tt.want {
+				t.Errorf("better() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package combustion
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// approachEpsilonC is the guard band, in Celsius, below ambient at which a
+// sample is considered too close to ambient for the log-linear fit to
+// remain numerically stable.
+const approachEpsilonC = 0.5
+
+// Prediction is a single ETA estimate emitted by a Predictor.
+type Prediction struct {
+	CoreTemp   float32       // latest virtual core reading, in Celsius
+	TargetTemp float32       // the target passed to Combustion.Predict, in Celsius
+	ETA        time.Duration // estimated time remaining until TargetTemp is reached
+	Confidence time.Duration // +/- half-width of the rolling confidence interval on ETA
+}
+
+// Predictor fits an exponential-approach cook model,
+// T(t) = Tamb - (Tamb-T0)*exp(-k*t), to a probe's packet stream via online
+// least squares on ln(Tamb-T) vs. t, and emits an ETA for a target
+// temperature as new packets arrive.
+type Predictor struct {
+	serial  string
+	targetC float32
+	out     chan Prediction
+
+	mu        sync.Mutex
+	startedAt time.Time
+	fit       linearFit
+}
+
+// Predict starts fitting a cook model for the probe with the given serial
+// number and returns a channel of ETA predictions toward targetC (in
+// Celsius). StartMonitoring must already be running, since the predictor is
+// fed from the same packet stream.
+func (c *Combustion) Predict(serial string, targetC float32) (<-chan Prediction, error) {
+	if c.BluetoothAdapter == nil {
+		return nil, errors.New("combustion: Predict requires StartMonitoring to already be running")
+	}
+
+	p := &Predictor{
+		serial:  serial,
+		targetC: targetC,
+		out:     make(chan Prediction, 1),
+	}
+
+	c.mu.Lock()
+	c.predictors = append(c.predictors, p)
+	c.mu.Unlock()
+
+	return p.out, nil
+}
+
+func (c *Combustion) feedPredictors(packet CombustionPacket) {
+	c.mu.Lock()
+	predictors := append([]*Predictor(nil), c.predictors...)
+	c.mu.Unlock()
+
+	for _, p := range predictors {
+		if p.serial == packet.SerialNumber {
+			p.observe(packet)
+		}
+	}
+}
+
+func (p *Predictor) observe(packet CombustionPacket) {
+	if len(packet.Temps) <= int(packet.VirtualAmbientIndex) || len(packet.Temps) <= int(packet.VirtualCoreIndex) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.startedAt.IsZero() {
+		p.startedAt = now
+	}
+	t := now.Sub(p.startedAt).Seconds()
+
+	ambient := float64(packet.Temps[packet.VirtualAmbientIndex])
+	core := float64(packet.Temps[packet.VirtualCoreIndex])
+	target := float64(p.targetC)
+
+	if core >= ambient-approachEpsilonC {
+		return // too close to ambient for the log-linear fit to be stable
+	}
+
+	p.fit.add(t, math.Log(ambient-core))
+
+	slope, intercept, stderr, ok := p.fit.solve()
+	if !ok || slope >= 0 {
+		return // not enough samples yet, or the probe isn't approaching ambient
+	}
+	k := -slope
+
+	if target >= ambient-approachEpsilonC {
+		return // target is not reachable from the current ambient reading; the fit itself is still fine
+	}
+
+	tEta := (intercept - math.Log(ambient-target)) / k
+	remaining := tEta - t
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	prediction := Prediction{
+		CoreTemp:   float32(core),
+		TargetTemp: p.targetC,
+		ETA:        time.Duration(remaining * float64(time.Second)),
+		Confidence: time.Duration(math.Abs(stderr/k) * float64(time.Second)),
+	}
+
+	select {
+	case p.out <- prediction:
+	default:
+		// Drop if the consumer hasn't read the previous prediction yet; the
+		// next packet will produce a fresher one anyway.
+	}
+}
+
+// linearFit is an online (running-sum) ordinary least squares accumulator
+// for y = intercept + slope*x, so Predictor never needs to retain sample
+// history.
+type linearFit struct {
+	n                   int
+	sumX, sumY          float64
+	sumXX, sumXY, sumYY float64
+}
+
+func (f *linearFit) add(x, y float64) {
+	f.n++
+	f.sumX += x
+	f.sumY += y
+	f.sumXX += x * x
+	f.sumXY += x * y
+	f.sumYY += y * y
+}
+
+// solve returns the slope and intercept of the best fit line along with the
+// residual standard error. ok is false until enough samples have been seen.
+func (f *linearFit) solve() (slope, intercept, stderr float64, ok bool) {
+	if f.n < 3 {
+		return 0, 0, 0, false
+	}
+
+	n := float64(f.n)
+	denom := n*f.sumXX - f.sumX*f.sumX
+	if denom == 0 {
+		return 0, 0, 0, false
+	}
+
+	slope = (n*f.sumXY - f.sumX*f.sumY) / denom
+	intercept = (f.sumY - slope*f.sumX) / n
+
+	// Residual sum of squares, expanded from the accumulated sums so no
+	// sample history needs to be retained.
+	rss := f.sumYY - intercept*f.sumY - slope*f.sumXY
+	if rss < 0 {
+		rss = 0
+	}
+	stderr = math.Sqrt(rss / (n - 2))
+
+	return slope, intercept, stderr, true
+}
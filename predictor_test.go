@@ -0,0 +1,66 @@
+package combustion
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearFitSolve(t *testing.T) {
+	var fit linearFit
+
+	if _, _, _, ok := fit.solve(); ok {
+		t.Fatalf("solve() on an empty fit should report ok=false")
+	}
+
+	// y = 3 - 2x, sampled exactly, so the fit should recover slope/intercept
+	// with ~zero residual error.
+	const wantSlope = -2.0
+	const wantIntercept = 3.0
+	for x := 0.0; x < 5; x++ {
+		fit.add(x, wantIntercept+wantSlope*x)
+	}
+
+	slope, intercept, stderr, ok := fit.solve()
+	if !ok {
+		t.Fatalf("solve() = ok=false, want true")
+	}
+	if math.Abs(slope-wantSlope) > 1e-9 {
+		t.Errorf("slope = %v, want %v", slope, wantSlope)
+	}
+	if math.Abs(intercept-wantIntercept) > 1e-9 {
+		t.Errorf("intercept = %v, want %v", intercept, wantIntercept)
+	}
+	if stderr > 1e-9 {
+		t.Errorf("stderr = %v, want ~0 for an exact fit", stderr)
+	}
+}
+
+func TestPredictorObserveKeepsFittingNearTarget(t *testing.T) {
+	// Regression test: a target close to ambient must only suppress the
+	// final ETA computation, not starve the underlying fit of samples.
+	p := &Predictor{
+		serial:  "abc123",
+		targetC: 54.7, // within approachEpsilonC of the 55C ambient used below
+		out:     make(chan Prediction, 1),
+	}
+
+	packet := CombustionPacket{
+		SerialNumber:        p.serial,
+		Temps:               []float32{30, 0, 0, 0, 55},
+		VirtualCoreIndex:    0,
+		VirtualAmbientIndex: 4,
+	}
+
+	for i := 0; i < 5; i++ {
+		p.observe(packet)
+		packet.Temps[0] += 2 // core approaches ambient
+	}
+
+	p.mu.Lock()
+	n := p.fit.n
+	p.mu.Unlock()
+
+	if n == 0 {
+		t.Fatalf("fit received no samples; a near-ambient target must not block fit.add")
+	}
+}
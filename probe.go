@@ -0,0 +1,311 @@
+package combustion
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// defaultRequestTimeout bounds how long ReadLog/SetPredictionTarget wait for
+// a response before giving up, so a disconnect or an unresponsive probe
+// can't hang the caller forever.
+const defaultRequestTimeout = 5 * time.Second
+
+// See https://github.com/combustion-inc/combustion-documentation/blob/main/probe_ble_specification.rst#services
+var (
+	uartServiceUUID = mustParseUUID("00000100-CAAB-3792-3D44-97AE51C1407A")
+	uartRXCharUUID  = mustParseUUID("00000101-CAAB-3792-3D44-97AE51C1407A")
+	uartTXCharUUID  = mustParseUUID("00000102-CAAB-3792-3D44-97AE51C1407A")
+
+	statusServiceUUID = mustParseUUID("00000200-CAAB-3792-3D44-97AE51C1407A")
+	statusCharUUID    = mustParseUUID("00000201-CAAB-3792-3D44-97AE51C1407A")
+)
+
+func mustParseUUID(s string) bluetooth.UUID {
+	uuid, err := bluetooth.ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return uuid
+}
+
+// StatusPacket carries the same fields as CombustionPacket but arrives over
+// a GATT notification on an active connection rather than an advertisement.
+type StatusPacket = CombustionPacket
+
+// LogRecord is a single historical sample pulled from a probe's onboard
+// temperature log.
+type LogRecord struct {
+	SequenceNumber uint32
+	Temps          []float32
+}
+
+// See https://github.com/combustion-inc/combustion-documentation/blob/main/probe_ble_specification.rst#request-response-format
+type uartOpcode byte
+
+const (
+	opcodeReadLogs            uartOpcode = 0x04
+	opcodeSetPredictionTarget uartOpcode = 0x05
+)
+
+type uartResponse struct {
+	Opcode  uartOpcode
+	Success bool
+	Payload []byte
+}
+
+// Probe is an active GATT connection to a Combustion probe, opened via
+// Combustion.Connect. It exposes the UART-style command service and the
+// probe-status service documented at
+// https://github.com/combustion-inc/combustion-documentation/blob/main/probe_ble_specification.rst#services
+type Probe struct {
+	combustion *Combustion
+	device     bluetooth.Device
+
+	uartRX bluetooth.DeviceCharacteristic // write: host -> probe requests
+	uartTX bluetooth.DeviceCharacteristic // notify: probe -> host responses
+	status bluetooth.DeviceCharacteristic // notify: probe status pushes
+
+	mu            sync.Mutex
+	pending       map[uartOpcode]chan uartResponse
+	statusHandler func(StatusPacket)
+
+	// requestMu serializes request() round-trips. pending is keyed by
+	// opcode, so two overlapping requests for the same opcode would
+	// otherwise clobber each other's response channel.
+	requestMu sync.Mutex
+
+	// requestTimeout overrides defaultRequestTimeout when non-zero; tests
+	// use this to exercise the timeout path without waiting 5 seconds.
+	requestTimeout time.Duration
+}
+
+// Connect opens a GATT connection to a previously scanned Combustion probe
+// and discovers the UART-style command service and the probe-status
+// service. The returned Probe can pull historical log records, change the
+// prediction target, and subscribe to live status pushes -- unlike
+// StartMonitoring, which only observes advertisement data.
+func (c *Combustion) Connect(device bluetooth.ScanResult) (*Probe, error) {
+	if c.BluetoothAdapter == nil {
+		c.BluetoothAdapter = bluetooth.DefaultAdapter
+	}
+
+	conn, err := c.BluetoothAdapter.Connect(device.Address, bluetooth.ConnectionParams{})
+	if err != nil {
+		return nil, fmt.Errorf("combustion: connect: %w", err)
+	}
+
+	services, err := conn.DiscoverServices([]bluetooth.UUID{uartServiceUUID, statusServiceUUID})
+	if err != nil {
+		conn.Disconnect()
+		return nil, fmt.Errorf("combustion: discover services: %w", err)
+	}
+
+	probe := &Probe{
+		combustion: c,
+		device:     conn,
+		pending:    make(map[uartOpcode]chan uartResponse),
+	}
+
+	for _, service := range services {
+		switch service.UUID() {
+		case uartServiceUUID:
+			err = probe.bindUARTService(service)
+		case statusServiceUUID:
+			err = probe.bindStatusService(service)
+		}
+
+		if err != nil {
+			conn.Disconnect()
+			return nil, err
+		}
+	}
+
+	return probe, nil
+}
+
+func (p *Probe) bindUARTService(service bluetooth.DeviceService) error {
+	chars, err := service.DiscoverCharacteristics([]bluetooth.UUID{uartRXCharUUID, uartTXCharUUID})
+	if err != nil {
+		return fmt.Errorf("combustion: discover uart characteristics: %w", err)
+	}
+
+	for _, char := range chars {
+		switch char.UUID() {
+		case uartRXCharUUID:
+			p.uartRX = char
+		case uartTXCharUUID:
+			p.uartTX = char
+		}
+	}
+
+	return p.uartTX.EnableNotifications(p.onUARTResponse)
+}
+
+func (p *Probe) bindStatusService(service bluetooth.DeviceService) error {
+	chars, err := service.DiscoverCharacteristics([]bluetooth.UUID{statusCharUUID})
+	if err != nil {
+		return fmt.Errorf("combustion: discover status characteristics: %w", err)
+	}
+
+	for _, char := range chars {
+		if char.UUID() == statusCharUUID {
+			p.status = char
+		}
+	}
+
+	return p.status.EnableNotifications(p.onStatusNotification)
+}
+
+// ReadLog pulls historical temperature samples in the [from, to) sequence
+// number range from the probe's onboard log. See
+// https://github.com/combustion-inc/combustion-documentation/blob/main/probe_ble_specification.rst#log-request
+func (p *Probe) ReadLog(from, to uint32) ([]LogRecord, error) {
+	payload := make([]byte, 8)
+	binary.LittleEndian.PutUint32(payload[0:4], from)
+	binary.LittleEndian.PutUint32(payload[4:8], to)
+
+	resp, err := p.request(opcodeReadLogs, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeLogRecords(p.combustion, resp.Payload), nil
+}
+
+// decodeLogRecords splits a ReadLog response payload into LogRecords. Any
+// trailing bytes short of a full record are ignored.
+func decodeLogRecords(combustion *Combustion, payload []byte) []LogRecord {
+	const recordSize = 4 + 8*2 // sequence number + 8 raw thermistor readings
+
+	records := make([]LogRecord, 0, len(payload)/recordSize)
+	for offset := 0; offset+recordSize <= len(payload); offset += recordSize {
+		chunk := payload[offset : offset+recordSize]
+		record := LogRecord{
+			SequenceNumber: binary.LittleEndian.Uint32(chunk[0:4]),
+			Temps:          make([]float32, 8),
+		}
+
+		for i := 0; i < 8; i++ {
+			raw := binary.LittleEndian.Uint16(chunk[4+i*2 : 6+i*2])
+			record.Temps[i] = combustion.fromRawTemp(raw)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// SetPredictionTarget updates the probe's reference/prediction target
+// temperature, in degrees Celsius.
+func (p *Probe) SetPredictionTarget(tempC float32) error {
+	payload := make([]byte, 4)
+	binary.LittleEndian.PutUint32(payload, math.Float32bits(tempC))
+
+	_, err := p.request(opcodeSetPredictionTarget, payload)
+	return err
+}
+
+// OnStatus registers a callback invoked whenever the probe pushes a status
+// notification over its active connection.
+func (p *Probe) OnStatus(callback func(StatusPacket)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statusHandler = callback
+}
+
+// Close disconnects from the probe.
+func (p *Probe) Close() error {
+	return p.device.Disconnect()
+}
+
+func (p *Probe) request(opcode uartOpcode, payload []byte) (uartResponse, error) {
+	// Held for the whole round-trip: pending is keyed by opcode, so a second
+	// call for the same opcode started before this one finishes would
+	// otherwise replace this call's response channel in the map.
+	p.requestMu.Lock()
+	defer p.requestMu.Unlock()
+
+	frame := append([]byte{byte(opcode)}, payload...)
+	frame = append(frame, checksum(frame))
+
+	respCh := make(chan uartResponse, 1)
+	p.mu.Lock()
+	p.pending[opcode] = respCh
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(p.pending, opcode)
+		p.mu.Unlock()
+	}()
+
+	if _, err := p.uartRX.WriteWithoutResponse(frame); err != nil {
+		return uartResponse{}, fmt.Errorf("combustion: write request: %w", err)
+	}
+
+	return p.awaitResponse(opcode, respCh)
+}
+
+func (p *Probe) awaitResponse(opcode uartOpcode, respCh <-chan uartResponse) (uartResponse, error) {
+	timeout := p.requestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	var resp uartResponse
+	select {
+	case resp = <-respCh:
+	case <-time.After(timeout):
+		return uartResponse{}, fmt.Errorf("combustion: timed out waiting for opcode 0x%02x response", opcode)
+	}
+
+	if !resp.Success {
+		return uartResponse{}, fmt.Errorf("combustion: probe rejected opcode 0x%02x", opcode)
+	}
+
+	return resp, nil
+}
+
+func (p *Probe) onUARTResponse(buf []byte) {
+	if len(buf) < 3 {
+		return
+	}
+
+	opcode := uartOpcode(buf[0])
+	success := buf[1] == 0x01
+	payload := buf[2 : len(buf)-1] // trailing checksum byte
+
+	p.mu.Lock()
+	respCh, ok := p.pending[opcode]
+	p.mu.Unlock()
+
+	if ok {
+		respCh <- uartResponse{Opcode: opcode, Success: success, Payload: payload}
+	}
+}
+
+func (p *Probe) onStatusNotification(buf []byte) {
+	packet := p.combustion.ExtractCombustionPacket(buf)
+
+	p.mu.Lock()
+	handler := p.statusHandler
+	p.mu.Unlock()
+
+	if handler != nil {
+		handler(StatusPacket(*packet))
+	}
+}
+
+func checksum(frame []byte) byte {
+	var sum byte
+	for _, b := range frame {
+		sum ^= b
+	}
+	return sum
+}
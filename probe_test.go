@@ -0,0 +1,97 @@
+package combustion
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []byte
+		want  byte
+	}{
+		{"empty", []byte{}, 0x00},
+		{"single byte", []byte{0x05}, 0x05},
+		{"xor of several bytes", []byte{0x04, 0x01, 0x02, 0x03}, 0x04 ^ 0x01 ^ 0x02 ^ 0x03},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checksum(tt.frame); got != tt.want {
+				t.Errorf("checksum(%v) = %#x, want %#x", tt.frame, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeLogRecords(t *testing.T) {
+	c := &Combustion{}
+
+	appendRecord := func(payload []byte, seq uint32, raw uint16) []byte {
+		record := make([]byte, 20)
+		binary.LittleEndian.PutUint32(record[0:4], seq)
+		for i := 0; i < 8; i++ {
+			binary.LittleEndian.PutUint16(record[4+i*2:6+i*2], raw)
+		}
+		return append(payload, record...)
+	}
+
+	var payload []byte
+	payload = appendRecord(payload, 1, 0)   // (0*0.05)-20 = -20
+	payload = appendRecord(payload, 2, 400) // (400*0.05)-20 = 0
+
+	records := decodeLogRecords(c, payload)
+	if len(records) != 2 {
+		t.Fatalf("len(records) = %d, want 2", len(records))
+	}
+	if records[0].SequenceNumber != 1 || records[1].SequenceNumber != 2 {
+		t.Errorf("unexpected sequence numbers: %+v", records)
+	}
+	if records[0].Temps[0] != -20 {
+		t.Errorf("records[0].Temps[0] = %v, want -20", records[0].Temps[0])
+	}
+	if records[1].Temps[0] != 0 {
+		t.Errorf("records[1].Temps[0] = %v, want 0", records[1].Temps[0])
+	}
+
+	// A trailing partial record must be dropped, not panic.
+	partial := append(payload, 0x01, 0x02, 0x03)
+	if got := decodeLogRecords(c, partial); len(got) != 2 {
+		t.Errorf("len(decodeLogRecords with trailing partial record) = %d, want 2", len(got))
+	}
+}
+
+func TestAwaitResponseSuccess(t *testing.T) {
+	p := &Probe{requestTimeout: 50 * time.Millisecond}
+	respCh := make(chan uartResponse, 1)
+	respCh <- uartResponse{Opcode: opcodeReadLogs, Success: true, Payload: []byte{0x01}}
+
+	resp, err := p.awaitResponse(opcodeReadLogs, respCh)
+	if err != nil {
+		t.Fatalf("awaitResponse: %v", err)
+	}
+	if len(resp.Payload) != 1 || resp.Payload[0] != 0x01 {
+		t.Errorf("resp.Payload = %v, want [0x01]", resp.Payload)
+	}
+}
+
+func TestAwaitResponseRejected(t *testing.T) {
+	p := &Probe{requestTimeout: 50 * time.Millisecond}
+	respCh := make(chan uartResponse, 1)
+	respCh <- uartResponse{Opcode: opcodeSetPredictionTarget, Success: false}
+
+	if _, err := p.awaitResponse(opcodeSetPredictionTarget, respCh); err == nil {
+		t.Fatal("expected an error for a rejected request, got nil")
+	}
+}
+
+func TestAwaitResponseTimeout(t *testing.T) {
+	p := &Probe{requestTimeout: 10 * time.Millisecond}
+	respCh := make(chan uartResponse) // never sent to
+
+	if _, err := p.awaitResponse(opcodeReadLogs, respCh); err == nil {
+		t.Fatal("expected a timeout error, got nil")
+	}
+}
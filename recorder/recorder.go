@@ -0,0 +1,204 @@
+// Package recorder persists every packet observed by a Combustion to a
+// pluggable Sink, opening and closing sessions per probe serial number
+// automatically so users don't have to reinvent session bookkeeping per
+// project.
+package recorder
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	combustion "github.com/carsonmcdonald/go-combustion"
+)
+
+// DefaultSessionGap is how long a probe serial can go unseen before the
+// next packet starts a new session instead of continuing the old one.
+const DefaultSessionGap = 10 * time.Minute
+
+// Session identifies one continuous recording for a single probe serial
+// number.
+type Session struct {
+	ID           string
+	SerialNumber string
+	StartedAt    time.Time
+}
+
+// Sample is one recorded packet, flattened for storage.
+type Sample struct {
+	Timestamp       time.Time
+	SerialNumber    string
+	ProbeID         byte
+	Temps           [8]float32
+	Mode            combustion.CombustionMode
+	BatteryOK       bool
+	OverheatingMask byte
+}
+
+// Sink persists sessions and their samples. Built-in implementations are
+// provided for CSV (CSVSink), SQLite (SQLiteSink), and InfluxDB line
+// protocol (InfluxSink).
+type Sink interface {
+	StartSession(session Session) error
+	WriteSample(session Session, sample Sample) error
+	EndSession(session Session) error
+	Close() error
+}
+
+// ReplaySink is implemented by sinks that can play a recorded session back,
+// so tests and post-cook analyses can run against recorded data without
+// hardware.
+type ReplaySink interface {
+	Replay(sessionID string, callback func(combustion.CombustionPacket)) error
+}
+
+// Recorder hooks a Combustion's packet handler and writes every packet to a
+// Sink, opening and closing sessions per probe serial number automatically
+// and rolling a probe over to a new session after a long gap in packets.
+type Recorder struct {
+	sink       Sink
+	sessionGap time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+	lastSeen map[string]time.Time
+}
+
+// New creates a Recorder that writes to sink, rolling a probe's session
+// over to a new one once sessionGap has passed without a packet. A
+// sessionGap of 0 uses DefaultSessionGap.
+func New(sink Sink, sessionGap time.Duration) *Recorder {
+	if sessionGap <= 0 {
+		sessionGap = DefaultSessionGap
+	}
+
+	return &Recorder{
+		sink:       sink,
+		sessionGap: sessionGap,
+		sessions:   make(map[string]*Session),
+		lastSeen:   make(map[string]time.Time),
+	}
+}
+
+// Attach hooks c's packet handler via StartMonitoring and begins recording.
+// It blocks the same way Combustion.StartMonitoring does. Once it returns
+// (for example after the caller calls c.StopMonitoring), call Close to
+// finalize whatever sessions are still open.
+func (r *Recorder) Attach(c *combustion.Combustion) error {
+	return c.StartMonitoring(r.observe)
+}
+
+// Close ends every session still open -- the common case being the final
+// session for each probe, which never sees a later packet to trigger the
+// gap-based rollover in sessionFor -- and then closes the underlying sink.
+// Call it once Attach returns.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	open := make([]Session, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		open = append(open, *session)
+	}
+	r.sessions = make(map[string]*Session)
+	r.mu.Unlock()
+
+	var firstErr error
+	for _, session := range open {
+		if err := r.sink.EndSession(session); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if err := r.sink.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	return firstErr
+}
+
+func (r *Recorder) observe(_ *combustion.Combustion, packet combustion.CombustionPacket) {
+	now := time.Now()
+
+	session, err := r.sessionFor(packet.SerialNumber, now)
+	if err != nil {
+		return
+	}
+
+	if err := r.sink.WriteSample(*session, sampleFrom(now, packet)); err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.lastSeen[packet.SerialNumber] = now
+	r.mu.Unlock()
+}
+
+func (r *Recorder) sessionFor(serial string, now time.Time) (*Session, error) {
+	r.mu.Lock()
+	session, ok := r.sessions[serial]
+	lastSeen, seenBefore := r.lastSeen[serial]
+	stale := ok && seenBefore && now.Sub(lastSeen) > r.sessionGap
+	r.mu.Unlock()
+
+	if ok && !stale {
+		return session, nil
+	}
+
+	if stale {
+		if err := r.sink.EndSession(*session); err != nil {
+			return nil, err
+		}
+	}
+
+	session = &Session{
+		ID:           fmt.Sprintf("%s-%d", serial, now.UnixNano()),
+		SerialNumber: serial,
+		StartedAt:    now,
+	}
+
+	if err := r.sink.StartSession(*session); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.sessions[serial] = session
+	r.mu.Unlock()
+
+	return session, nil
+}
+
+// Replay plays a previously recorded session back through callback, so
+// tests and post-cook analyses can run against recorded data without
+// hardware. The Recorder's sink must implement ReplaySink.
+func (r *Recorder) Replay(sessionID string, callback func(combustion.CombustionPacket)) error {
+	replayable, ok := r.sink.(ReplaySink)
+	if !ok {
+		return fmt.Errorf("recorder: sink %T does not support replay", r.sink)
+	}
+
+	return replayable.Replay(sessionID, callback)
+}
+
+func sampleFrom(ts time.Time, packet combustion.CombustionPacket) Sample {
+	sample := Sample{
+		Timestamp:    ts,
+		SerialNumber: packet.SerialNumber,
+		ProbeID:      packet.ProbeID,
+		Mode:         packet.Mode,
+		BatteryOK:    packet.BatteryOK,
+	}
+
+	for i, temp := range packet.Temps {
+		if i >= len(sample.Temps) {
+			break
+		}
+		sample.Temps[i] = temp
+	}
+
+	for i, overheating := range packet.Overheating {
+		if overheating {
+			sample.OverheatingMask |= 1 << i
+		}
+	}
+
+	return sample
+}
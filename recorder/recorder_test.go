@@ -0,0 +1,89 @@
+package recorder
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeSink struct {
+	started []Session
+	ended   []Session
+	samples []Sample
+	closed  bool
+}
+
+func (f *fakeSink) StartSession(session Session) error {
+	f.started = append(f.started, session)
+	return nil
+}
+
+func (f *fakeSink) EndSession(session Session) error {
+	f.ended = append(f.ended, session)
+	return nil
+}
+
+func (f *fakeSink) WriteSample(_ Session, sample Sample) error {
+	f.samples = append(f.samples, sample)
+	return nil
+}
+
+func (f *fakeSink) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestRecorderSessionRollover(t *testing.T) {
+	sink := &fakeSink{}
+	r := New(sink, time.Minute)
+
+	t0 := time.Now()
+	first, err := r.sessionFor("serial1", t0)
+	if err != nil {
+		t.Fatalf("sessionFor: %v", err)
+	}
+
+	// Well within the gap: same session.
+	second, err := r.sessionFor("serial1", t0.Add(10*time.Second))
+	if err != nil {
+		t.Fatalf("sessionFor: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("expected session to continue, got new session %q want %q", second.ID, first.ID)
+	}
+
+	r.mu.Lock()
+	r.lastSeen["serial1"] = t0.Add(10 * time.Second)
+	r.mu.Unlock()
+
+	// Past the gap: new session, and the old one is ended.
+	third, err := r.sessionFor("serial1", t0.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("sessionFor: %v", err)
+	}
+	if third.ID == first.ID {
+		t.Errorf("expected rollover to a new session after the gap, still on %q", third.ID)
+	}
+	if len(sink.ended) != 1 || sink.ended[0].ID != first.ID {
+		t.Errorf("expected the stale session to be ended, got %+v", sink.ended)
+	}
+}
+
+func TestRecorderCloseFinalizesOpenSessions(t *testing.T) {
+	sink := &fakeSink{}
+	r := New(sink, time.Minute)
+
+	if _, err := r.sessionFor("serial1", time.Now()); err != nil {
+		t.Fatalf("sessionFor: %v", err)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if len(sink.ended) != 1 {
+		t.Fatalf("expected the open session to be ended on Close, got %+v", sink.ended)
+	}
+	if !sink.closed {
+		t.Errorf("expected the sink to be closed")
+	}
+}
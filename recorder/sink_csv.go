@@ -0,0 +1,135 @@
+package recorder
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+
+	combustion "github.com/carsonmcdonald/go-combustion"
+)
+
+var csvHeader = []string{
+	"session_id", "ts", "serial", "probe_id",
+	"t1", "t2", "t3", "t4", "t5", "t6", "t7", "t8",
+	"mode", "battery_ok", "overheating_mask",
+}
+
+// CSVSink appends every sample to a single CSV file, one row per sample,
+// with a session_id column distinguishing sessions.
+type CSVSink struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+// NewCSVSink opens (or creates) path and appends to it, writing a header
+// row only when the file is new.
+func NewCSVSink(path string) (*CSVSink, error) {
+	info, statErr := os.Stat(path)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: open csv sink: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	if statErr != nil || info.Size() == 0 {
+		if err := writer.Write(csvHeader); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("recorder: write csv header: %w", err)
+		}
+		writer.Flush()
+	}
+
+	return &CSVSink{file: file, writer: writer}, nil
+}
+
+func (s *CSVSink) StartSession(Session) error { return nil }
+func (s *CSVSink) EndSession(Session) error   { return nil }
+
+func (s *CSVSink) WriteSample(session Session, sample Sample) error {
+	row := make([]string, 0, len(csvHeader))
+	row = append(row,
+		session.ID,
+		strconv.FormatInt(sample.Timestamp.UnixMilli(), 10),
+		sample.SerialNumber,
+		strconv.Itoa(int(sample.ProbeID)),
+	)
+
+	for _, t := range sample.Temps {
+		row = append(row, strconv.FormatFloat(float64(t), 'f', 2, 32))
+	}
+
+	row = append(row,
+		strconv.Itoa(int(sample.Mode)),
+		strconv.FormatBool(sample.BatteryOK),
+		strconv.Itoa(int(sample.OverheatingMask)),
+	)
+
+	if err := s.writer.Write(row); err != nil {
+		return fmt.Errorf("recorder: write csv row: %w", err)
+	}
+	s.writer.Flush()
+
+	return s.writer.Error()
+}
+
+func (s *CSVSink) Close() error {
+	s.writer.Flush()
+	return s.file.Close()
+}
+
+// Replay reads back every row recorded for sessionID, in file order.
+func (s *CSVSink) Replay(sessionID string, callback func(combustion.CombustionPacket)) error {
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil {
+		return err
+	}
+
+	file, err := os.Open(s.file.Name())
+	if err != nil {
+		return fmt.Errorf("recorder: open csv sink for replay: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return fmt.Errorf("recorder: read csv sink: %w", err)
+	}
+
+	for _, row := range rows[1:] { // skip header
+		if row[0] != sessionID {
+			continue
+		}
+		callback(packetFromCSVRow(row))
+	}
+
+	return nil
+}
+
+func packetFromCSVRow(row []string) combustion.CombustionPacket {
+	packet := combustion.CombustionPacket{
+		SerialNumber: row[2],
+		Temps:        make([]float32, 8),
+	}
+
+	probeID, _ := strconv.Atoi(row[3])
+	packet.ProbeID = byte(probeID)
+
+	for i := 0; i < 8; i++ {
+		t, _ := strconv.ParseFloat(row[4+i], 32)
+		packet.Temps[i] = float32(t)
+	}
+
+	mode, _ := strconv.Atoi(row[12])
+	packet.Mode = combustion.CombustionMode(mode)
+
+	packet.BatteryOK, _ = strconv.ParseBool(row[13])
+
+	mask, _ := strconv.Atoi(row[14])
+	for i := 0; i < 8; i++ {
+		packet.Overheating[i] = mask&(1<<i) != 0
+	}
+
+	return packet
+}
@@ -0,0 +1,79 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// InfluxSink writes every sample as an InfluxDB line-protocol point, either
+// over UDP or by POSTing to an HTTP write endpoint.
+type InfluxSink struct {
+	measurement string
+	udpConn     net.Conn
+	httpURL     string
+	httpClient  *http.Client
+}
+
+// NewInfluxUDPSink writes line-protocol points to addr (host:port) over
+// UDP, as used by InfluxDB's UDP listener.
+func NewInfluxUDPSink(addr, measurement string) (*InfluxSink, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: dial influx udp sink: %w", err)
+	}
+
+	return &InfluxSink{measurement: measurement, udpConn: conn}, nil
+}
+
+// NewInfluxHTTPSink POSTs line-protocol points to writeURL, an InfluxDB
+// /api/v2/write or /write endpoint including its auth/bucket query params.
+func NewInfluxHTTPSink(writeURL, measurement string) *InfluxSink {
+	return &InfluxSink{
+		measurement: measurement,
+		httpURL:     writeURL,
+		httpClient:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *InfluxSink) StartSession(Session) error { return nil }
+func (s *InfluxSink) EndSession(Session) error    { return nil }
+
+func (s *InfluxSink) WriteSample(session Session, sample Sample) error {
+	line := fmt.Sprintf(
+		"%s,session_id=%s,serial=%s,probe_id=%d t1=%f,t2=%f,t3=%f,t4=%f,t5=%f,t6=%f,t7=%f,t8=%f,mode=%di,battery_ok=%t,overheating_mask=%di %d\n",
+		s.measurement, session.ID, sample.SerialNumber, sample.ProbeID,
+		sample.Temps[0], sample.Temps[1], sample.Temps[2], sample.Temps[3],
+		sample.Temps[4], sample.Temps[5], sample.Temps[6], sample.Temps[7],
+		sample.Mode, sample.BatteryOK, sample.OverheatingMask,
+		sample.Timestamp.UnixNano(),
+	)
+
+	if s.udpConn != nil {
+		if _, err := s.udpConn.Write([]byte(line)); err != nil {
+			return fmt.Errorf("recorder: write influx udp point: %w", err)
+		}
+		return nil
+	}
+
+	resp, err := s.httpClient.Post(s.httpURL, "text/plain; charset=utf-8", bytes.NewBufferString(line))
+	if err != nil {
+		return fmt.Errorf("recorder: post influx point: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("recorder: influx write rejected: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *InfluxSink) Close() error {
+	if s.udpConn != nil {
+		return s.udpConn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package recorder
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	combustion "github.com/carsonmcdonald/go-combustion"
+
+	_ "modernc.org/sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS sessions (
+	id TEXT PRIMARY KEY,
+	serial_number TEXT NOT NULL,
+	started_at INTEGER NOT NULL,
+	ended_at INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS samples (
+	session_id TEXT NOT NULL,
+	ts INTEGER NOT NULL,
+	serial TEXT NOT NULL,
+	probe_id INTEGER NOT NULL,
+	t1 REAL, t2 REAL, t3 REAL, t4 REAL, t5 REAL, t6 REAL, t7 REAL, t8 REAL,
+	mode INTEGER NOT NULL,
+	battery_ok INTEGER NOT NULL,
+	overheating_mask INTEGER NOT NULL
+);
+`
+
+// SQLiteSink persists sessions and samples to a SQLite database file, using
+// the sessions/samples schema in sqliteSchema.
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+// NewSQLiteSink opens (or creates) the SQLite database at path and ensures
+// the sessions and samples tables exist.
+func NewSQLiteSink(path string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: open sqlite sink: %w", err)
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("recorder: create sqlite schema: %w", err)
+	}
+
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) StartSession(session Session) error {
+	_, err := s.db.Exec(
+		`INSERT INTO sessions (id, serial_number, started_at) VALUES (?, ?, ?)`,
+		session.ID, session.SerialNumber, session.StartedAt.UnixMilli(),
+	)
+	if err != nil {
+		return fmt.Errorf("recorder: start sqlite session: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteSink) EndSession(session Session) error {
+	_, err := s.db.Exec(
+		`UPDATE sessions SET ended_at = ? WHERE id = ?`,
+		time.Now().UnixMilli(), session.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("recorder: end sqlite session: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteSink) WriteSample(session Session, sample Sample) error {
+	_, err := s.db.Exec(
+		`INSERT INTO samples (session_id, ts, serial, probe_id, t1, t2, t3, t4, t5, t6, t7, t8, mode, battery_ok, overheating_mask)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		session.ID, sample.Timestamp.UnixMilli(), sample.SerialNumber, sample.ProbeID,
+		sample.Temps[0], sample.Temps[1], sample.Temps[2], sample.Temps[3],
+		sample.Temps[4], sample.Temps[5], sample.Temps[6], sample.Temps[7],
+		sample.Mode, sample.BatteryOK, sample.OverheatingMask,
+	)
+	if err != nil {
+		return fmt.Errorf("recorder: write sqlite sample: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteSink) Close() error {
+	return s.db.Close()
+}
+
+// Replay plays every sample recorded for sessionID back through callback,
+// ordered by timestamp.
+func (s *SQLiteSink) Replay(sessionID string, callback func(combustion.CombustionPacket)) error {
+	rows, err := s.db.Query(
+		`SELECT serial, probe_id, t1, t2, t3, t4, t5, t6, t7, t8, mode, battery_ok, overheating_mask
+		 FROM samples WHERE session_id = ? ORDER BY ts ASC`,
+		sessionID,
+	)
+	if err != nil {
+		return fmt.Errorf("recorder: query sqlite samples: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			serial          string
+			probeID         byte
+			t               [8]float32
+			mode            combustion.CombustionMode
+			batteryOK       bool
+			overheatingMask byte
+		)
+
+		if err := rows.Scan(&serial, &probeID, &t[0], &t[1], &t[2], &t[3], &t[4], &t[5], &t[6], &t[7], &mode, &batteryOK, &overheatingMask); err != nil {
+			return fmt.Errorf("recorder: scan sqlite sample: %w", err)
+		}
+
+		packet := combustion.CombustionPacket{
+			SerialNumber: serial,
+			ProbeID:      probeID,
+			Temps:        append([]float32(nil), t[:]...),
+			Mode:         mode,
+			BatteryOK:    batteryOK,
+		}
+		for i := 0; i < 8; i++ {
+			packet.Overheating[i] = overheatingMask&(1<<i) != 0
+		}
+
+		callback(packet)
+	}
+
+	return rows.Err()
+}